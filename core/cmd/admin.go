@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	clipkg "github.com/urfave/cli"
+)
+
+// reloadConfigHandler triggers watcher.Reload over HTTP, so the CLI command
+// below (and any other operator tooling) can ask a running node to reload
+// without shell access to its process.
+func reloadConfigHandler(watcher *ConfigWatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		watcher.Reload()
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// AdminReloadConfig asks a running node to reload its hot-reloadable config
+// fields, equivalent to sending it a SIGHUP, for operators who only have
+// access to the node's HTTP API.
+func (cli *Client) AdminReloadConfig(c *clipkg.Context) error {
+	url, err := adminReloadURL()
+	if err != nil {
+		return cli.errorOut(fmt.Errorf("error parsing admin listen address: %+v", err))
+	}
+	resp, err := http.Post(url, "application/json", nil)
+	if err != nil {
+		return cli.errorOut(fmt.Errorf("error reloading config: %+v", err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return cli.errorOut(fmt.Errorf("node returned status %s reloading config", resp.Status))
+	}
+	fmt.Println("Config reload triggered")
+	return nil
+}
+
+// adminReloadURL builds the /v2/config/reload URL from adminListenAddr(),
+// which may be a bare ":port" or a full "host:port" (e.g. "0.0.0.0:6689"
+// for binding inside a container). Either way this always dials localhost:
+// AdminReloadConfig only ever talks to the node running on the same host.
+func adminReloadURL() (string, error) {
+	_, port, err := net.SplitHostPort(adminListenAddr())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("http://%s/v2/config/reload", net.JoinHostPort("localhost", port)), nil
+}
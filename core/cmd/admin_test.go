@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAdminListenAddr_Default(t *testing.T) {
+	os.Unsetenv(adminAddrEnvVar) // nolint:errcheck
+	if got := adminListenAddr(); got != ":6689" {
+		t.Fatalf("expected default address :6689, got %s", got)
+	}
+}
+
+func TestAdminListenAddr_EnvOverride(t *testing.T) {
+	defer os.Unsetenv(adminAddrEnvVar)  // nolint:errcheck
+	os.Setenv(adminAddrEnvVar, ":9999") // nolint:errcheck
+	if got := adminListenAddr(); got != ":9999" {
+		t.Fatalf("expected overridden address :9999, got %s", got)
+	}
+}
+
+func TestAdminReloadURL_BarePort(t *testing.T) {
+	defer os.Unsetenv(adminAddrEnvVar)  // nolint:errcheck
+	os.Setenv(adminAddrEnvVar, ":9999") // nolint:errcheck
+	got, err := adminReloadURL()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if want := "http://localhost:9999/v2/config/reload"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestAdminReloadURL_HostAndPort(t *testing.T) {
+	defer os.Unsetenv(adminAddrEnvVar)         // nolint:errcheck
+	os.Setenv(adminAddrEnvVar, "0.0.0.0:9999") // nolint:errcheck
+	got, err := adminReloadURL()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if want := "http://localhost:9999/v2/config/reload"; got != want {
+		t.Fatalf("expected the host to always resolve to localhost, got %s", got)
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"chainlink/core/logger"
+	"chainlink/core/services/metrics"
+	"chainlink/core/store/orm"
+)
+
+// hotReloadableFields lists the orm.Config fields ConfigWatcher applies to a
+// running node without a restart: log level, minimum confirmations, gas
+// bump thresholds, and the HTTP CORS allow-list.
+var hotReloadableFields = []string{
+	"LogLevel",
+	"MinOutgoingConfirmations",
+	"EthGasBumpThreshold",
+	"EthGasBumpWei",
+	"AllowOrigins",
+}
+
+// parseHotReloadableField converts the raw env var string for field into
+// the typed value orm.Config.Set expects for it, so a reload never hands
+// the config a string where a downstream accessor expects a number.
+func parseHotReloadableField(field, raw string) (interface{}, error) {
+	switch field {
+	case "MinOutgoingConfirmations", "EthGasBumpThreshold":
+		v, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an unsigned integer, got %q: %+v", raw, err)
+		}
+		return v, nil
+	case "EthGasBumpWei":
+		v, ok := new(big.Int).SetString(raw, 10)
+		if !ok {
+			return nil, fmt.Errorf("expected an integer, got %q", raw)
+		}
+		return v, nil
+	default:
+		// LogLevel and AllowOrigins are consumed as plain strings.
+		return raw, nil
+	}
+}
+
+// watchedNonReloadableFields are env-backed fields ConfigWatcher also
+// watches purely so it can warn operators that a restart is needed; it
+// never applies these to the running config.
+var watchedNonReloadableFields = []string{
+	"RootDir",
+	"EthereumURL",
+	"DatabaseURL",
+}
+
+// ReloadHandler is implemented by subsystems that can apply a config change
+// to a running chainlink.Application without a restart.
+type ReloadHandler interface {
+	OnConfigReload(changed map[string]string)
+}
+
+// ConfigWatcher reloads orm.Config from the environment on SIGHUP (or an
+// explicit Reload call from the admin reload-config command), diffs it
+// against its last snapshot, and fans the changed hot-reloadable keys out
+// to registered ReloadHandlers. Keys that changed but aren't
+// hot-reloadable are logged as requiring a restart instead of applied.
+type ConfigWatcher struct {
+	config *orm.Config
+	log    *logger.Logger
+
+	mu          sync.Mutex
+	snapshot    map[string]string
+	nonReloaded map[string]string
+	handlers    []ReloadHandler
+}
+
+// NewConfigWatcher snapshots the current value of every watched field so
+// the first Reload has something to diff against.
+func NewConfigWatcher(config *orm.Config) *ConfigWatcher {
+	w := &ConfigWatcher{
+		config: config,
+		log:    logger.New("subsys", "config_watcher"),
+	}
+	w.snapshot = readEnvFields(hotReloadableFields)
+	w.nonReloaded = readEnvFields(watchedNonReloadableFields)
+	return w
+}
+
+// Register adds a handler to be notified when a hot-reloadable field
+// changes.
+func (w *ConfigWatcher) Register(h ReloadHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, h)
+}
+
+// WatchSIGHUP starts a goroutine that calls Reload every time the process
+// receives SIGHUP, until stop is closed.
+func (w *ConfigWatcher) WatchSIGHUP(stop <-chan struct{}) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-sigs:
+				w.Reload()
+			case <-stop:
+				signal.Stop(sigs)
+				return
+			}
+		}
+	}()
+}
+
+// Reload re-reads every watched field from the environment. Changed
+// hot-reloadable fields are parsed to their real type and applied directly
+// to w.config, which txmanager and friends already consult live on every
+// use — that alone is enough for MinOutgoingConfirmations, the gas bump
+// fields, and AllowOrigins. LogLevel additionally gets a registered
+// ReloadHandler because the zap level it controls is cached outside
+// w.config and won't pick up a plain Set. Changed non-reloadable fields are
+// only logged, since applying them without restarting the relevant
+// subsystem would leave the node in an inconsistent state.
+func (w *ConfigWatcher) Reload() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	metrics.ConfigReloadTotal.Inc()
+
+	updated := readEnvFields(hotReloadableFields)
+	changed := map[string]string{}
+	for field, newVal := range updated {
+		if w.snapshot[field] == newVal {
+			continue
+		}
+		parsed, err := parseHotReloadableField(field, newVal)
+		if err != nil {
+			w.log.Warnw("ignoring reloaded value that failed to parse", "field", field, "value", newVal, "error", err)
+			continue
+		}
+		w.config.Set(orm.EnvVarName(field), parsed)
+		changed[field] = newVal
+	}
+	w.snapshot = updated
+
+	updatedNonReloaded := readEnvFields(watchedNonReloadableFields)
+	var needsRestart []string
+	for field, newVal := range updatedNonReloaded {
+		if w.nonReloaded[field] != newVal {
+			needsRestart = append(needsRestart, field)
+		}
+	}
+	w.nonReloaded = updatedNonReloaded
+
+	if len(needsRestart) > 0 {
+		w.log.Warnw("config changed for fields that require a restart to take effect", "fields", needsRestart)
+	}
+	if len(changed) == 0 {
+		w.log.Info("config reload requested, no hot-reloadable changes detected")
+		return
+	}
+	w.log.Infow("reloaded config", "changed", changed)
+	for _, h := range w.handlers {
+		h.OnConfigReload(changed)
+	}
+}
+
+// reloadHandlerFunc adapts a plain function to ReloadHandler, mirroring how
+// http.HandlerFunc adapts a function to http.Handler elsewhere in this
+// package.
+type reloadHandlerFunc func(changed map[string]string)
+
+func (f reloadHandlerFunc) OnConfigReload(changed map[string]string) {
+	f(changed)
+}
+
+// logLevelReloadHandler applies a reloaded LogLevel field to the package
+// logger immediately, without requiring CreateProductionLogger to run
+// again.
+type logLevelReloadHandler struct{}
+
+func (logLevelReloadHandler) OnConfigReload(changed map[string]string) {
+	lvl, ok := changed["LogLevel"]
+	if !ok {
+		return
+	}
+	if err := logger.SetLevelString(lvl); err != nil {
+		logger.New("subsys", "config_watcher").Warnw("ignoring invalid reloaded log level", "level", lvl, "error", err)
+	}
+}
+
+func readEnvFields(fields []string) map[string]string {
+	vals := make(map[string]string, len(fields))
+	for _, field := range fields {
+		vals[field] = os.Getenv(orm.EnvVarName(field))
+	}
+	return vals
+}
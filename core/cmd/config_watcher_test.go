@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseHotReloadableField_Uints(t *testing.T) {
+	for _, field := range []string{"MinOutgoingConfirmations", "EthGasBumpThreshold"} {
+		got, err := parseHotReloadableField(field, "12")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %+v", field, err)
+		}
+		if got != uint64(12) {
+			t.Fatalf("%s: expected uint64(12), got %#v", field, got)
+		}
+	}
+}
+
+func TestParseHotReloadableField_EthGasBumpWei(t *testing.T) {
+	got, err := parseHotReloadableField("EthGasBumpWei", "5000000000")
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	bi, ok := got.(*big.Int)
+	if !ok {
+		t.Fatalf("expected *big.Int, got %#v", got)
+	}
+	if bi.Cmp(big.NewInt(5000000000)) != 0 {
+		t.Fatalf("expected 5000000000, got %s", bi.String())
+	}
+}
+
+func TestParseHotReloadableField_StringPassthrough(t *testing.T) {
+	for _, field := range []string{"LogLevel", "AllowOrigins"} {
+		got, err := parseHotReloadableField(field, "debug")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %+v", field, err)
+		}
+		if got != "debug" {
+			t.Fatalf("%s: expected passthrough string, got %#v", field, got)
+		}
+	}
+}
+
+func TestParseHotReloadableField_RejectsUnparseable(t *testing.T) {
+	if _, err := parseHotReloadableField("MinOutgoingConfirmations", "not-a-number"); err == nil {
+		t.Fatal("expected an error for an unparseable uint, got nil")
+	}
+	if _, err := parseHotReloadableField("EthGasBumpWei", "not-a-number"); err == nil {
+		t.Fatal("expected an error for an unparseable big.Int, got nil")
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// minProductionScryptN is the minimum scrypt N parameter accepted from an
+// imported keystore file. N controls how expensive brute-forcing the
+// password is, so anything weaker is rejected outright rather than
+// silently imported.
+const minProductionScryptN = 1 << 18
+
+// encryptedKeyJSON is the subset of a go-ethereum V3 keystore file needed to
+// read its address and KDF parameters ahead of a full decrypt.
+type encryptedKeyJSON struct {
+	Address string `json:"address"`
+	Crypto  struct {
+		KDF       string `json:"kdf"`
+		KDFParams struct {
+			N int `json:"n"`
+		} `json:"kdfparams"`
+	} `json:"crypto"`
+}
+
+// decryptAndValidateKey parses keyJSON, rejects keystores using weaker than
+// production scrypt parameters, and verifies it decrypts under pwd. It
+// returns the checksummed address the key belongs to.
+func decryptAndValidateKey(keyJSON []byte, pwd string) (string, error) {
+	var enc encryptedKeyJSON
+	if err := json.Unmarshal(keyJSON, &enc); err != nil {
+		return "", fmt.Errorf("not a valid keystore file: %+v", err)
+	}
+	if enc.Crypto.KDF == "scrypt" && enc.Crypto.KDFParams.N < minProductionScryptN {
+		return "", fmt.Errorf("keystore scrypt N=%d is weaker than the minimum of %d required for production use", enc.Crypto.KDFParams.N, minProductionScryptN)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, pwd)
+	if err != nil {
+		return "", fmt.Errorf("key does not decrypt with the node's password: %+v", err)
+	}
+	return key.Address.Hex(), nil
+}
+
+// keyFingerprint returns a stable SHA-256 fingerprint of a keystore file's
+// contents, so ListKeys can report on-disk key identity without re-parsing
+// the ciphertext each time.
+func keyFingerprint(keyJSON []byte) string {
+	sum := sha256.Sum256(keyJSON)
+	return hex.EncodeToString(sum[:])
+}
+
+// keyFileSuffix is the part of a key's filename that identifies which
+// address it belongs to, shared by every file ever written for that
+// address.
+func keyFileSuffix(address string) string {
+	return fmt.Sprintf("--%s.json", address)
+}
+
+// destKeyPath returns the path a freshly imported key for address should be
+// written to. Keys are kept flat, directly under keysDir (the same
+// directory go-ethereum's keystore.NewKeyStore, and this node's
+// SyncDiskKeyStoreToDB, scan non-recursively), named
+// UTC--<ts>--<address>.json so imports are content-addressed without
+// changing where the rest of the node looks for keys.
+func destKeyPath(keysDir, address string) string {
+	ts := time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z")
+	return filepath.Join(keysDir, fmt.Sprintf("UTC--%s%s", ts, keyFileSuffix(address)))
+}
+
+// keyFilesForAddress returns every key file already on disk for address,
+// sorted chronologically (the UTC--<ts>-- prefix sorts lexicographically in
+// timestamp order).
+func keyFilesForAddress(keysDir, address string) ([]string, error) {
+	entries, err := ioutil.ReadDir(keysDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	suffix := keyFileSuffix(address)
+	var matches []string
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), suffix) {
+			matches = append(matches, filepath.Join(keysDir, entry.Name()))
+		}
+	}
+	return matches, nil
+}
+
+// existingFingerprints returns the fingerprints of every key file already
+// stored for address, to detect a duplicate import.
+func existingFingerprints(keysDir, address string) (map[string]bool, error) {
+	fingerprints := map[string]bool{}
+	paths, err := keyFilesForAddress(keysDir, address)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		dat, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints[keyFingerprint(dat)] = true
+	}
+	return fingerprints, nil
+}
+
+// writeKeyFileAtomically writes keyJSON to dst via a temp file in the same
+// directory followed by os.Rename, so a crash or a concurrent reader never
+// observes a partial write. Permissions are locked to 0600 regardless of
+// the process umask.
+func writeKeyFileAtomically(dst string, keyJSON []byte) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.FileMode(0700)); err != nil {
+		return err
+	}
+	oldUmask := syscall.Umask(0077)
+	defer syscall.Umask(oldUmask)
+
+	tmp := dst + ".tmp"
+	if err := ioutil.WriteFile(tmp, keyJSON, os.FileMode(0600)); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp) // nolint:errcheck
+		return err
+	}
+	return nil
+}
+
+// latestKeyFile returns the most recently written key file for address.
+func latestKeyFile(keysDir, address string) (string, error) {
+	paths, err := keyFilesForAddress(keysDir, address)
+	if err != nil {
+		return "", err
+	}
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no key found for address %s", address)
+	}
+	return paths[len(paths)-1], nil
+}
+
+// listKeyAddresses returns the distinct addresses with at least one key
+// file directly under keysDir.
+func listKeyAddresses(keysDir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(keysDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var addresses []string
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		parts := strings.Split(name, "--")
+		address := parts[len(parts)-1]
+		if !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
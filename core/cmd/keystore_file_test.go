@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// newTestKeyJSON generates a real go-ethereum V3 keystore file via
+// keystore.NewKeyStore/NewAccount, so the encoding matches production
+// exactly, and returns its bytes plus the checksummed address it holds.
+func newTestKeyJSON(t *testing.T, password string, scryptN, scryptP int) ([]byte, string) {
+	t.Helper()
+	genDir, err := ioutil.TempDir("", "chainlink-keystore-gen")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %+v", err)
+	}
+	defer os.RemoveAll(genDir)
+
+	ks := ethkeystore.NewKeyStore(genDir, scryptN, scryptP)
+	account, err := ks.NewAccount(password)
+	if err != nil {
+		t.Fatalf("error creating test account: %+v", err)
+	}
+	keyJSON, err := ioutil.ReadFile(account.URL.Path)
+	if err != nil {
+		t.Fatalf("error reading generated key file: %+v", err)
+	}
+	return keyJSON, account.Address.Hex()
+}
+
+func TestDecryptAndValidateKey_Success(t *testing.T) {
+	keyJSON, address := newTestKeyJSON(t, "correcthorse", ethkeystore.StandardScryptN, ethkeystore.StandardScryptP)
+
+	got, err := decryptAndValidateKey(keyJSON, "correcthorse")
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if got != address {
+		t.Fatalf("expected address %s, got %s", address, got)
+	}
+}
+
+func TestDecryptAndValidateKey_WrongPassword(t *testing.T) {
+	keyJSON, _ := newTestKeyJSON(t, "correcthorse", ethkeystore.StandardScryptN, ethkeystore.StandardScryptP)
+
+	if _, err := decryptAndValidateKey(keyJSON, "wrongpassword"); err == nil {
+		t.Fatal("expected an error decrypting with the wrong password, got nil")
+	}
+}
+
+func TestDecryptAndValidateKey_WeakScrypt(t *testing.T) {
+	keyJSON, _ := newTestKeyJSON(t, "correcthorse", ethkeystore.LightScryptN, ethkeystore.LightScryptP)
+
+	_, err := decryptAndValidateKey(keyJSON, "correcthorse")
+	if err == nil {
+		t.Fatal("expected weak scrypt params to be rejected, got nil error")
+	}
+}
+
+func TestWriteKeyFileAtomically(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chainlink-keystore-test")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyJSON, address := newTestKeyJSON(t, "correcthorse", ethkeystore.LightScryptN, ethkeystore.LightScryptP)
+	dst := destKeyPath(dir, address)
+
+	if err := writeKeyFileAtomically(dst, keyJSON); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		t.Fatalf("expected key file to exist at %s: %+v", dst, err)
+	}
+	if perm := info.Mode().Perm(); perm != os.FileMode(0600) {
+		t.Fatalf("expected key file perms 0600, got %s", perm)
+	}
+	if filepath.Dir(dst) != dir {
+		t.Fatalf("expected key to be written flat under %s, got %s", dir, dst)
+	}
+	if _, err := os.Stat(dst + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("expected no leftover temp file, stat returned: %+v", err)
+	}
+}
+
+func TestExistingFingerprintsDetectsDuplicateImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chainlink-keystore-test")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyJSON, address := newTestKeyJSON(t, "correcthorse", ethkeystore.LightScryptN, ethkeystore.LightScryptP)
+	dst := destKeyPath(dir, address)
+	if err := writeKeyFileAtomically(dst, keyJSON); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	seen, err := existingFingerprints(dir, address)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if !seen[keyFingerprint(keyJSON)] {
+		t.Fatal("expected existingFingerprints to detect the key just written")
+	}
+}
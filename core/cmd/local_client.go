@@ -1,21 +1,25 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
-	"strings"
+	"strconv"
+	"time"
 
 	"chainlink/core/logger"
 	"chainlink/core/services/chainlink"
+	"chainlink/core/services/metrics"
 	strpkg "chainlink/core/store"
 	"chainlink/core/store/models"
 	"chainlink/core/store/orm"
 	"chainlink/core/store/presenters"
-	"chainlink/core/utils"
+	"chainlink/core/store/secrets"
 
 	clipkg "github.com/urfave/cli"
 	"go.uber.org/zap/zapcore"
@@ -24,49 +28,162 @@ import (
 // ownerPermsMask are the file permission bits reserved for owner.
 const ownerPermsMask = os.FileMode(0700)
 
+// Recognized values for the --secrets-backend flag.
+const (
+	secretsBackendFile  = "file"
+	secretsBackendVault = "vault"
+)
+
+// secretsProviderFromFlags builds the strpkg.SecretsProvider indicated by
+// --secrets-backend, defaulting to the historical on-disk file provider
+// when the flag is unset.
+func secretsProviderFromFlags(c *clipkg.Context) (strpkg.SecretsProvider, error) {
+	switch backend := c.String("secrets-backend"); backend {
+	case "", secretsBackendFile:
+		return strpkg.NewFileSecretsProvider(c.String("password"), c.String("api")), nil
+	case secretsBackendVault:
+		vaultCfg := secrets.VaultConfig{
+			Address:          os.Getenv("VAULT_ADDR"),
+			Path:             c.String("secrets-path"),
+			AuthMethod:       secrets.AuthMethod(c.String("vault-auth-method")),
+			Token:            os.Getenv("VAULT_TOKEN"),
+			RoleID:           os.Getenv("VAULT_ROLE_ID"),
+			SecretID:         os.Getenv("VAULT_SECRET_ID"),
+			KubeRole:         c.String("vault-kube-role"),
+			KubeJWTMountPath: c.String("vault-kube-jwt-path"),
+		}
+		return secrets.NewVaultProvider(vaultCfg)
+	default:
+		return nil, fmt.Errorf("unknown --secrets-backend %q", backend)
+	}
+}
+
+// inMemoryAPIInitializer seeds the node's API user directly from
+// credentials already held in memory, for secrets backends (e.g. Vault)
+// whose entire point is to never drop a secret to local disk -- writing
+// them out to a temp file first, even a 0600 one under os.TempDir() (which
+// checkFilePermissions doesn't walk), would defeat that.
+type inMemoryAPIInitializer struct {
+	email, password string
+}
+
+// Initialize implements the same interface as NewFileAPIInitializer, but
+// builds the user from i.email/i.password instead of reading them off disk.
+func (i inMemoryAPIInitializer) Initialize(store *strpkg.Store) (models.User, error) {
+	user, err := models.NewUser(i.email, i.password)
+	if err != nil {
+		return models.User{}, err
+	}
+	if err := store.SaveUser(&user); err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
 // RunNode starts the Chainlink core.
 func (cli *Client) RunNode(c *clipkg.Context) error {
+	startedAt := time.Now()
 	updateConfig(cli.Config, c.Bool("debug"), c.Int64("replay-from-block"))
 	logger.SetLogger(cli.Config.CreateProductionLogger())
-	logger.Infow("Starting Chainlink Node " + strpkg.Version + " at commit " + strpkg.Sha)
+	bootLog := logger.New("subsys", "bootstrap", "chain_id", cli.Config.ChainID())
+	bootLog.Infow("Starting Chainlink Node " + strpkg.Version + " at commit " + strpkg.Sha)
+
+	health := metrics.NewHealthStatus()
+	adminMux := http.NewServeMux()
+	adminMux.HandleFunc("/v2/log_level", logLevelHandler)
+	adminMux.Handle("/metrics", metrics.Handler())
+	adminMux.HandleFunc("/health", health.HealthHandler)
+	adminMux.HandleFunc("/ready", health.ReadyHandler)
+
+	configWatcher := NewConfigWatcher(cli.Config)
+	configWatcher.Register(logLevelReloadHandler{})
+	stopWatcher := make(chan struct{})
+	configWatcher.WatchSIGHUP(stopWatcher)
+	defer close(stopWatcher)
+	adminMux.HandleFunc("/v2/config/reload", reloadConfigHandler(configWatcher))
+
+	// A dedicated mux, not the process-global http.DefaultServeMux: RunNode
+	// can be called more than once in the same process (integration tests
+	// commonly do this), and registering the same pattern twice on the
+	// default mux panics. Binding the listener here, before returning,
+	// means a second RunNode using the same (default, unless
+	// CHAINLINK_ADMIN_ADDR is set per instance) admin address fails loudly
+	// with "address already in use" instead of silently serving no admin
+	// endpoints for that instance.
+	adminListener, err := net.Listen("tcp", adminListenAddr())
+	if err != nil {
+		return cli.errorOut(fmt.Errorf("error starting admin HTTP server: %+v", err))
+	}
+	go func() {
+		if err := http.Serve(adminListener, adminMux); err != nil {
+			bootLog.Errorw("admin HTTP server stopped", "error", err)
+		}
+	}()
 
-	err := InitEnclave()
+	err = InitEnclave()
 	if err != nil {
 		return cli.errorOut(fmt.Errorf("error initializing SGX enclave: %+v", err))
 	}
 
 	app := cli.AppFactory.NewApplication(cli.Config, func(app chainlink.Application) {
 		store := app.GetStore()
-		logNodeBalance(store)
-		logIfNonceOutOfSync(store)
+		logNodeBalance(store, health)
+		logIfNonceOutOfSync(store, health)
 	})
 	store := app.GetStore()
-	if err := checkFilePermissions(cli.Config.RootDir()); err != nil {
-		return cli.errorOut(err)
+
+	secretsProvider, err := secretsProviderFromFlags(c)
+	if err != nil {
+		return cli.errorOut(fmt.Errorf("error constructing secrets provider: %+v", err))
 	}
-	pwd, err := passwordFromFile(c.String("password"))
+	// File-backed secrets still live on disk as 0600 password/credential
+	// files, so the permissions sweep still applies. When secrets never
+	// touch disk (e.g. Vault), there's nothing local left to check.
+	if c.String("secrets-backend") == "" || c.String("secrets-backend") == secretsBackendFile {
+		if err := checkFilePermissions(cli.Config.RootDir()); err != nil {
+			return cli.errorOut(err)
+		}
+	}
+	pwd, err := secretsProvider.KeystorePassword()
 	if err != nil {
 		return cli.errorOut(fmt.Errorf("error reading password: %+v", err))
 	}
+	keystoreLog := logger.New("subsys", "keystore", "chain_id", cli.Config.ChainID())
 	_, err = cli.KeyStoreAuthenticator.Authenticate(store, pwd)
 	if err != nil {
 		return cli.errorOut(fmt.Errorf("error authenticating keystore: %+v", err))
 	}
-
-	var user models.User
-	if _, err = NewFileAPIInitializer(c.String("api")).Initialize(store); err != nil && err != errNoCredentialFile {
+	health.SetKeystoreUnlocked()
+
+	// Only the Vault backend sources API credentials from the
+	// SecretsProvider: the file backend's FileSecretsProvider.APICredentials
+	// reads the same .api file NewFileAPIInitializer already parses below,
+	// so deferring to it here would just duplicate that work and its error
+	// handling.
+	if c.String("secrets-backend") == secretsBackendVault {
+		email, password, err := secretsProvider.APICredentials()
+		if err != nil {
+			return cli.errorOut(fmt.Errorf("error reading API credentials from secrets provider: %+v", err))
+		}
+		if _, err = (inMemoryAPIInitializer{email: email, password: password}).Initialize(store); err != nil {
+			return cli.errorOut(fmt.Errorf("error creating api initializer: %+v", err))
+		}
+	} else if _, err = NewFileAPIInitializer(c.String("api")).Initialize(store); err != nil && err != errNoCredentialFile {
 		return cli.errorOut(fmt.Errorf("error creating api initializer: %+v", err))
 	}
+
+	var user models.User
 	if user, err = cli.FallbackAPIInitializer.Initialize(store); err != nil {
 		if err == ErrorNoAPICredentialsAvailable {
 			return cli.errorOut(err)
 		}
 		return cli.errorOut(fmt.Errorf("error creating fallback initializer: %+v", err))
 	}
-	logger.Info("API exposed for user ", user.Email)
+	keystoreLog.Infow("API exposed", "account", user.Email)
 	if err := app.Start(); err != nil {
 		return cli.errorOut(fmt.Errorf("error starting app: %+v", err))
 	}
+	metrics.StartupDuration.Set(time.Since(startedAt).Seconds())
 	defer loggedStop(app)
 	err = logConfigVariables(store)
 	if err != nil {
@@ -100,27 +217,29 @@ func checkFilePermissions(directory string) error {
 	return nil
 }
 
-func passwordFromFile(pwdFile string) (string, error) {
-	if len(pwdFile) == 0 {
-		return "", nil
-	}
-	dat, err := ioutil.ReadFile(pwdFile)
-	return strings.TrimSpace(string(dat)), err
-}
-
-func logIfNonceOutOfSync(store *strpkg.Store) {
+func logIfNonceOutOfSync(store *strpkg.Store, health *metrics.HealthStatus) {
 	account := store.TxManager.NextActiveAccount()
 	if account == nil {
 		return
 	}
+	address := account.Address.Hex()
+	log := logger.New("subsys", "txmanager", "account", address, "chain_id", store.Config.ChainID())
 	lastNonce, err := store.GetLastNonce(account.Address)
 	if err != nil {
-		logger.Error("database error when checking nonce: ", err)
+		log.Errorw("database error when checking nonce", "error", err)
 		return
 	}
 
-	if localNonceIsNotCurrent(lastNonce, account.Nonce()) {
-		logger.Warn("The account is being used by another wallet and is not safe to use with chainlink")
+	metrics.AccountNonceLocal.WithLabelValues(address).Set(float64(lastNonce))
+	metrics.AccountNonceRemote.WithLabelValues(address).Set(float64(account.Nonce()))
+
+	outOfSync := localNonceIsNotCurrent(lastNonce, account.Nonce())
+	health.SetNonceOutOfSync(outOfSync)
+	if outOfSync {
+		metrics.NonceOutOfSync.WithLabelValues(address).Set(1)
+		log.Warn("The account is being used by another wallet and is not safe to use with chainlink")
+	} else {
+		metrics.NonceOutOfSync.WithLabelValues(address).Set(0)
 	}
 }
 
@@ -141,17 +260,56 @@ func updateConfig(config *orm.Config, debug bool, replayFromBlock int64) {
 	}
 }
 
-func logNodeBalance(store *strpkg.Store) {
+// minEthBalanceEnvVar configures the ETH balance floor under which /ready
+// fails closed. Unset (or unparseable) disables the check.
+const minEthBalanceEnvVar = "MINIMUM_ETH_BALANCE"
+
+// adminAddrEnvVar overrides the listen address for the /metrics, /health,
+// /ready, /v2/log_level, and /v2/config/reload endpoints. It defaults to a
+// port separate from the main API so the two servers never fight over one
+// listener.
+const adminAddrEnvVar = "CHAINLINK_ADMIN_ADDR"
+
+func adminListenAddr() string {
+	if addr := os.Getenv(adminAddrEnvVar); addr != "" {
+		return addr
+	}
+	return ":6689"
+}
+
+func logNodeBalance(store *strpkg.Store, health *metrics.HealthStatus) {
+	log := logger.New("subsys", "bootstrap", "chain_id", store.Config.ChainID())
+
+	floor, floorSet := 0.0, false
+	if raw := os.Getenv(minEthBalanceEnvVar); raw != "" {
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			floor, floorSet = f, true
+		}
+	}
+
+	ethLow := false
 	accounts, err := presenters.ShowEthBalance(store)
-	logger.WarnIf(err)
+	log.WarnIf(err)
 	for _, a := range accounts {
-		logger.Infow(a["message"], "address", a["address"], "ethBalance", a["balance"])
+		log.Infow(a["message"], "address", a["address"], "ethBalance", a["balance"])
+		address := fmt.Sprintf("%v", a["address"])
+		if bal, err := strconv.ParseFloat(fmt.Sprintf("%v", a["balance"]), 64); err == nil {
+			metrics.EthBalance.WithLabelValues(address).Set(bal)
+			if floorSet && bal < floor {
+				ethLow = true
+			}
+		}
 	}
+	health.SetEthBalanceLow(ethLow)
 
 	accounts, err = presenters.ShowLinkBalance(store)
-	logger.WarnIf(err)
+	log.WarnIf(err)
 	for _, a := range accounts {
-		logger.Infow(a["message"], "address", a["address"], "linkBalance", a["balance"])
+		log.Infow(a["message"], "address", a["address"], "linkBalance", a["balance"])
+		address := fmt.Sprintf("%v", a["address"])
+		if bal, err := strconv.ParseFloat(fmt.Sprintf("%v", a["balance"]), 64); err == nil {
+			metrics.LinkBalance.WithLabelValues(address).Set(bal)
+		}
 	}
 }
 
@@ -178,9 +336,21 @@ func (cli *Client) DeleteUser(c *clipkg.Context) error {
 	return err
 }
 
-// ImportKey imports a key to be used with the chainlink node
+// ImportKey imports a key to be used with the chainlink node. By default the
+// key is read from the filepath given as the command argument; with
+// --secrets-backend=vault it is instead fetched from the configured Vault
+// path under the field named by that argument.
+//
+// The key is validated before it ever touches disk: it must parse as a
+// keystore file, use production-strength scrypt parameters, and decrypt
+// under the node's password. It is then written atomically under a
+// content-addressed path, UTC--<ts>--<address>.json directly in KeysDir(),
+// so a crash mid-import can't leave a partial file, a second import of the
+// same key is a no-op rather than a silent overwrite, and
+// SyncDiskKeyStoreToDB's non-recursive scan of KeysDir() still finds it.
 func (cli *Client) ImportKey(c *clipkg.Context) error {
 	logger.SetLogger(cli.Config.CreateProductionLogger())
+	log := logger.New("subsys", "keystore", "chain_id", cli.Config.ChainID())
 	app := cli.AppFactory.NewApplication(cli.Config)
 
 	if !c.Args().Present() {
@@ -190,40 +360,89 @@ func (cli *Client) ImportKey(c *clipkg.Context) error {
 	src := c.Args().First()
 	kdir := cli.Config.KeysDir()
 
-	if !utils.FileExists(kdir) {
-		err := os.MkdirAll(kdir, os.FileMode(0700))
-		if err != nil {
-			return cli.errorOut(err)
-		}
+	secretsProvider, err := secretsProviderFromFlags(c)
+	if err != nil {
+		return cli.errorOut(fmt.Errorf("error constructing secrets provider: %+v", err))
+	}
+	keyJSON, err := secretsProvider.ImportedKey(src)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	pwd, err := secretsProvider.KeystorePassword()
+	if err != nil {
+		return cli.errorOut(fmt.Errorf("error reading password: %+v", err))
 	}
 
-	if i := strings.LastIndex(src, "/"); i < 0 {
-		kdir += "/" + src
-	} else {
-		kdir += src[strings.LastIndex(src, "/"):]
+	address, err := decryptAndValidateKey(keyJSON, pwd)
+	if err != nil {
+		return cli.errorOut(err)
 	}
 
-	if err := copyFile(src, kdir); err != nil {
+	seen, err := existingFingerprints(kdir, address)
+	if err != nil {
 		return cli.errorOut(err)
 	}
+	if seen[keyFingerprint(keyJSON)] {
+		log.Infow("key already imported, skipping", "account", address)
+		return app.GetStore().SyncDiskKeyStoreToDB()
+	}
+
+	dst := destKeyPath(kdir, address)
+	if err := writeKeyFileAtomically(dst, keyJSON); err != nil {
+		return cli.errorOut(err)
+	}
+	log.Infow("imported key", "account", address, "path", dst)
 
 	return app.GetStore().SyncDiskKeyStoreToDB()
 }
 
-func copyFile(src, dst string) error {
-	from, err := os.Open(src)
-	if err != nil {
-		return err
+// ExportKey writes the most recently imported keystore file for an address
+// to a destination path, the inverse of ImportKey.
+func (cli *Client) ExportKey(c *clipkg.Context) error {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("Must pass in an address and a destination filepath"))
 	}
-	defer from.Close()
+	address := c.Args().Get(0)
+	dst := c.Args().Get(1)
 
-	to, err := os.Create(dst)
+	src, err := latestKeyFile(cli.Config.KeysDir(), address)
 	if err != nil {
-		return err
+		return cli.errorOut(err)
+	}
+	keyJSON, err := ioutil.ReadFile(src)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	if err := ioutil.WriteFile(dst, keyJSON, os.FileMode(0600)); err != nil {
+		return cli.errorOut(err)
 	}
-	defer to.Close()
+	return nil
+}
 
-	_, err = io.Copy(to, from)
+// ListKeys reports, for every address with a key on disk, the most recently
+// written key file's address, scrypt KDF parameters, and SHA-256
+// fingerprint. Older key files for the same address are not listed.
+func (cli *Client) ListKeys(c *clipkg.Context) error {
+	addresses, err := listKeyAddresses(cli.Config.KeysDir())
+	if err != nil {
+		return cli.errorOut(err)
+	}
 
-	return err
+	for _, address := range addresses {
+		path, err := latestKeyFile(cli.Config.KeysDir(), address)
+		if err != nil {
+			return cli.errorOut(err)
+		}
+		dat, err := ioutil.ReadFile(path)
+		if err != nil {
+			return cli.errorOut(err)
+		}
+		var enc encryptedKeyJSON
+		if err := json.Unmarshal(dat, &enc); err != nil {
+			return cli.errorOut(err)
+		}
+		fmt.Printf("address: %s  kdf: %s  n: %d  fingerprint: %s\n",
+			address, enc.Crypto.KDF, enc.Crypto.KDFParams.N, keyFingerprint(dat))
+	}
+	return nil
 }
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"chainlink/core/logger"
+)
+
+// setLevelRequest is the body accepted by logLevelHandler's POST.
+type setLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// logLevelHandler lets operators flip the node's log level at runtime,
+// without a restart: GET reports the current level, POST {"level":"debug"}
+// changes it.
+func logLevelHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(setLevelRequest{Level: logger.GetLevel().String()}) // nolint:errcheck
+	case http.MethodPost:
+		var req setLevelRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := logger.SetLevelString(req.Level); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,17 @@
+package logger
+
+import "go.uber.org/zap"
+
+// New returns a child Logger carrying keyvals on every subsequent log line,
+// mirroring go-ethereum's log.New("key", val) pattern. Subsystems should
+// build one of these once at construction time (e.g. New("subsys", "orm"))
+// rather than calling the package-level functions, so log lines can be
+// filtered and correlated downstream.
+//
+// Its core is wrapped with levelOverrideCore, so SetLevel/SetLevelString
+// governs every subsystem logger built this way regardless of what level
+// Default itself was constructed with.
+func New(keyvals ...interface{}) *Logger {
+	desugared := Default.SugaredLogger.Desugar().WithOptions(zap.WrapCore(wrapWithLevelOverride))
+	return &Logger{SugaredLogger: desugared.Sugar().With(keyvals...)}
+}
@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// level is the package-wide atomic log level. CreateProductionLogger
+// predates this package and isn't part of this change, so Default's own
+// core doesn't consult it; instead, New wraps every child Logger's core
+// with levelOverrideCore below, which checks level on every log call. That
+// makes SetLevel/SetLevelString take effect immediately on every logger
+// built via New, without a restart.
+var level = zap.NewAtomicLevel()
+
+// SetLevel changes the level of every logger created via New at runtime.
+// Default and the package-level logging functions are not affected.
+func SetLevel(lvl zapcore.Level) {
+	level.SetLevel(lvl)
+}
+
+// SetLevelString parses lvl (e.g. "debug", "warn") and applies it via
+// SetLevel. It exists so callers working with plain strings, like the
+// admin reload-config command or an HTTP handler, don't need to import zap.
+func SetLevelString(lvl string) error {
+	var l zapcore.Level
+	if err := l.Set(lvl); err != nil {
+		return fmt.Errorf("invalid log level %q: %+v", lvl, err)
+	}
+	SetLevel(l)
+	return nil
+}
+
+// GetLevel returns the currently active log level.
+func GetLevel() zapcore.Level {
+	return level.Level()
+}
+
+// levelOverrideCore wraps a zapcore.Core, substituting level for whatever
+// level the wrapped core was itself built with, so a later SetLevel takes
+// effect on already-constructed Loggers instead of only new ones.
+type levelOverrideCore struct {
+	zapcore.Core
+}
+
+func wrapWithLevelOverride(core zapcore.Core) zapcore.Core {
+	return levelOverrideCore{core}
+}
+
+func (c levelOverrideCore) Enabled(lvl zapcore.Level) bool {
+	return level.Enabled(lvl)
+}
+
+func (c levelOverrideCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !level.Enabled(ent.Level) {
+		return ce
+	}
+	return ce.AddCore(ent, c)
+}
+
+func (c levelOverrideCore) With(fields []zapcore.Field) zapcore.Core {
+	return levelOverrideCore{c.Core.With(fields)}
+}
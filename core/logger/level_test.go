@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevelOverrideCore_SuppressesBelowCurrentLevel(t *testing.T) {
+	defer SetLevel(zapcore.InfoLevel)
+
+	observedCore, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(wrapWithLevelOverride(observedCore)).Sugar()
+
+	if err := SetLevelString("error"); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	log.Info("suppressed")
+	log.Error("kept")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected exactly 1 entry to pass the Error level, got %d", len(entries))
+	}
+	if entries[0].Message != "kept" {
+		t.Fatalf("expected the surviving entry to be the Error log, got %q", entries[0].Message)
+	}
+}
+
+func TestLevelOverrideCore_AllowsAfterLoweringLevel(t *testing.T) {
+	defer SetLevel(zapcore.InfoLevel)
+
+	observedCore, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(wrapWithLevelOverride(observedCore)).Sugar()
+
+	if err := SetLevelString("debug"); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	log.Debug("now visible")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected the Debug log to pass once level is lowered, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"net/http"
+	"sync"
+)
+
+// HealthStatus tracks the conditions /health and /ready fail-close on:
+// a locked keystore, nonce drift against the connected Ethereum node, and
+// an ETH balance under the configured floor. It starts pessimistic — not
+// ready — until RunNode reports otherwise.
+type HealthStatus struct {
+	mu             sync.RWMutex
+	keystoreLocked bool
+	nonceOutOfSync bool
+	ethBalanceLow  bool
+}
+
+// NewHealthStatus returns a HealthStatus that reports not-ready until the
+// keystore is unlocked. Nonce drift and a low ETH balance start cleared and
+// only fail readiness once reported via their setters.
+func NewHealthStatus() *HealthStatus {
+	return &HealthStatus{keystoreLocked: true}
+}
+
+// SetKeystoreUnlocked marks the keystore as authenticated.
+func (h *HealthStatus) SetKeystoreUnlocked() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.keystoreLocked = false
+}
+
+// SetNonceOutOfSync records whether the node's local nonce has diverged
+// from the remote one.
+func (h *HealthStatus) SetNonceOutOfSync(outOfSync bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nonceOutOfSync = outOfSync
+}
+
+// SetEthBalanceLow records whether the node's ETH balance has fallen under
+// the configured floor.
+func (h *HealthStatus) SetEthBalanceLow(low bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ethBalanceLow = low
+}
+
+// Ready reports whether the node is fit to serve traffic: keystore
+// unlocked, nonce in sync, and ETH balance above the floor.
+func (h *HealthStatus) Ready() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return !h.keystoreLocked && !h.nonceOutOfSync && !h.ethBalanceLow
+}
+
+// HealthHandler always returns 200 once the process is up; it only reports
+// that the node is alive, not that it's ready to serve traffic.
+func (h *HealthStatus) HealthHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// ReadyHandler fails closed (503) until Ready returns true.
+func (h *HealthStatus) ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.Ready() {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
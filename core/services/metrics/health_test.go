@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthStatus_Ready(t *testing.T) {
+	tests := []struct {
+		name           string
+		keystoreLocked bool
+		nonceOutOfSync bool
+		ethBalanceLow  bool
+		want           bool
+	}{
+		{"all clear", false, false, false, true},
+		{"keystore still locked", true, false, false, false},
+		{"nonce out of sync", false, true, false, false},
+		{"eth balance low", false, false, true, false},
+		{"everything wrong", true, true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthStatus()
+			if !tt.keystoreLocked {
+				h.SetKeystoreUnlocked()
+			}
+			h.SetNonceOutOfSync(tt.nonceOutOfSync)
+			h.SetEthBalanceLow(tt.ethBalanceLow)
+			if got := h.Ready(); got != tt.want {
+				t.Fatalf("Ready() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthStatus_ReadyHandler_FailsClosedUntilUnlocked(t *testing.T) {
+	h := NewHealthStatus()
+
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	rec := httptest.NewRecorder()
+	h.ReadyHandler(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 before the keystore unlocks, got %d", rec.Code)
+	}
+
+	h.SetKeystoreUnlocked()
+	rec = httptest.NewRecorder()
+	h.ReadyHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 once every condition clears, got %d", rec.Code)
+	}
+}
+
+func TestHealthStatus_HealthHandler_AlwaysOK(t *testing.T) {
+	h := NewHealthStatus()
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	h.HealthHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected /health to report 200 regardless of readiness, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,77 @@
+// Package metrics registers the Prometheus gauges and counters Chainlink's
+// bootstrap and transaction-manager paths publish, and exposes a
+// /health and /ready pair for k8s-style liveness/readiness probes.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// EthBalance is the node's current ETH balance per account.
+	EthBalance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "chainlink_eth_balance",
+		Help: "Current ETH balance of a Chainlink node account",
+	}, []string{"account"})
+
+	// LinkBalance is the node's current LINK balance per account.
+	LinkBalance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "chainlink_link_balance",
+		Help: "Current LINK balance of a Chainlink node account",
+	}, []string{"account"})
+
+	// AccountNonceLocal is the last nonce Chainlink has recorded locally for
+	// an account.
+	AccountNonceLocal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "chainlink_account_nonce_local",
+		Help: "Last nonce recorded locally by Chainlink for an account",
+	}, []string{"account"})
+
+	// AccountNonceRemote is the nonce the connected Ethereum node reports
+	// for an account.
+	AccountNonceRemote = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "chainlink_account_nonce_remote",
+		Help: "Nonce reported by the Ethereum node for an account",
+	}, []string{"account"})
+
+	// NonceOutOfSync is 1 when the local and remote nonces have diverged
+	// for an account, 0 otherwise.
+	NonceOutOfSync = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "chainlink_nonce_out_of_sync",
+		Help: "1 if the account's local nonce has fallen behind the remote nonce, 0 otherwise",
+	}, []string{"account"})
+
+	// StartupDuration is how long RunNode took from being called until the
+	// application finished starting.
+	StartupDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "chainlink_startup_duration_seconds",
+		Help: "Time taken for the node to complete bootstrap",
+	})
+
+	// ConfigReloadTotal counts every hot config reload, successful or not.
+	ConfigReloadTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "chainlink_config_reload_total",
+		Help: "Number of times the node has reloaded its hot-reloadable config",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		EthBalance,
+		LinkBalance,
+		AccountNonceLocal,
+		AccountNonceRemote,
+		NonceOutOfSync,
+		StartupDuration,
+		ConfigReloadTotal,
+	)
+}
+
+// Handler returns the /metrics handler to register on the node's HTTP
+// server.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
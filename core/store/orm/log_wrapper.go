@@ -1,14 +1,21 @@
 package orm
 
 import (
+	"os"
+	"time"
+
 	logpkg "github.com/smartcontractkit/chainlink/core/logger"
 	"go.uber.org/zap"
 )
 
 var logger *ormLogWrapper
 
+// defaultSlowSQLThreshold is used when LOG_SLOW_SQL_THRESHOLD is unset or
+// unparseable.
+const defaultSlowSQLThreshold = 100 * time.Millisecond
+
 func init() {
-	logger = newOrmLogWrapper(logpkg.Default)
+	logger = newOrmLogWrapper(logpkg.New("subsys", "orm"))
 }
 
 type ormLogWrapper struct {
@@ -28,6 +35,51 @@ func newOrmLogWrapper(logger *logpkg.Logger) *ormLogWrapper {
 	}
 }
 
+// gormCallbackLog is the typed shape of a GORM "sql" callback log line, in
+// place of indexing into args by position.
+type gormCallbackLog struct {
+	Caller       string
+	Duration     time.Duration
+	SQL          string
+	Vars         []interface{}
+	RowsAffected int64
+}
+
+// parseGormCallbackLog decodes the args GORM's logger callback passes for a
+// "sql" entry: [source, caller, duration, sql, vars, rowsAffected].
+func parseGormCallbackLog(args []interface{}) (gormCallbackLog, bool) {
+	if len(args) < 6 {
+		return gormCallbackLog{}, false
+	}
+	caller, _ := args[1].(string)
+	duration, _ := args[2].(time.Duration)
+	sql, _ := args[3].(string)
+	vars, _ := args[4].([]interface{})
+	rowsAffected, _ := args[5].(int64)
+	return gormCallbackLog{
+		Caller:       caller,
+		Duration:     duration,
+		SQL:          sql,
+		Vars:         vars,
+		RowsAffected: rowsAffected,
+	}, true
+}
+
+// slowSQLThreshold is the query duration above which a "sql" callback is
+// promoted from Debug to Warn, configurable via LOG_SLOW_SQL_THRESHOLD
+// (e.g. "200ms").
+func slowSQLThreshold() time.Duration {
+	raw := os.Getenv("LOG_SLOW_SQL_THRESHOLD")
+	if raw == "" {
+		return defaultSlowSQLThreshold
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return defaultSlowSQLThreshold
+	}
+	return d
+}
+
 func (l *ormLogWrapper) Print(args ...interface{}) {
 	switch args[0] {
 	case "error":
@@ -35,7 +87,22 @@ func (l *ormLogWrapper) Print(args ...interface{}) {
 	case "log":
 		l.Warn(args[2])
 	case "sql":
-		l.Debugw(args[3].(string), "time", args[2], "rows_affected", args[5])
+		entry, ok := parseGormCallbackLog(args)
+		if !ok {
+			l.Debugw("malformed GORM sql log entry", "args", args)
+			return
+		}
+		fields := []interface{}{
+			"sql", entry.SQL,
+			"duration_ms", float64(entry.Duration) / float64(time.Millisecond),
+			"rows_affected", entry.RowsAffected,
+			"caller", entry.Caller,
+		}
+		if entry.Duration >= slowSQLThreshold() {
+			l.Warnw("slow SQL query", fields...)
+			return
+		}
+		l.Debugw("SQL query", fields...)
 	default:
 		// Don't log these, only seems to be the callback logs which aren't super useful
 	}
@@ -0,0 +1,13 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"time"
+)
+
+// readFile and timeNow are indirected so tests can stub them out without
+// touching the real filesystem or clock.
+var (
+	readFile = ioutil.ReadFile
+	timeNow  = time.Now
+)
@@ -0,0 +1,188 @@
+// Package secrets provides SecretsProvider implementations that let
+// Chainlink read sensitive material (keystore password, API credentials,
+// imported keys) from a remote secrets manager instead of local files.
+package secrets
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// AuthMethod selects how the VaultProvider authenticates to Vault.
+type AuthMethod string
+
+const (
+	// AuthMethodToken authenticates with a static Vault token.
+	AuthMethodToken AuthMethod = "token"
+	// AuthMethodAppRole authenticates via the AppRole auth method.
+	AuthMethodAppRole AuthMethod = "approle"
+	// AuthMethodKubernetes authenticates via the Kubernetes auth method,
+	// using the pod's projected service account token.
+	AuthMethodKubernetes AuthMethod = "kubernetes"
+)
+
+// VaultConfig holds everything needed to reach and authenticate against a
+// Vault KV v2 mount.
+type VaultConfig struct {
+	Address    string
+	Path       string // e.g. "secret/data/chainlink/prod"
+	AuthMethod AuthMethod
+
+	// AuthMethodToken
+	Token string
+
+	// AuthMethodAppRole
+	RoleID   string
+	SecretID string
+
+	// AuthMethodKubernetes
+	KubeRole         string
+	KubeJWTMountPath string // defaults to /var/run/secrets/kubernetes.io/serviceaccount/token
+}
+
+// VaultProvider implements store.SecretsProvider against a Vault KV v2
+// backend. Leases obtained during login are cached and transparently
+// refreshed on expiry so a long-running node doesn't have to be restarted
+// when its token lease runs out.
+type VaultProvider struct {
+	cfg    VaultConfig
+	client *vaultapi.Client
+
+	mu        sync.Mutex
+	leaseTTL  time.Duration
+	renewedAt time.Time
+}
+
+// NewVaultProvider constructs a VaultProvider and performs an initial login
+// using the configured AuthMethod.
+func NewVaultProvider(cfg VaultConfig) (*VaultProvider, error) {
+	clientCfg := vaultapi.DefaultConfig()
+	clientCfg.Address = cfg.Address
+	client, err := vaultapi.NewClient(clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("vault: error creating client: %+v", err)
+	}
+
+	v := &VaultProvider{cfg: cfg, client: client}
+	if err := v.login(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// login authenticates to Vault using the configured AuthMethod and caches
+// the resulting lease duration so ensureFresh can tell when it has expired.
+func (v *VaultProvider) login() error {
+	switch v.cfg.AuthMethod {
+	case AuthMethodToken:
+		v.client.SetToken(v.cfg.Token)
+		v.leaseTTL = 0 // static tokens don't expire on their own
+		return nil
+	case AuthMethodAppRole:
+		secret, err := v.client.Logical().Write("auth/approle/login", map[string]interface{}{
+			"role_id":   v.cfg.RoleID,
+			"secret_id": v.cfg.SecretID,
+		})
+		if err != nil {
+			return fmt.Errorf("vault: approle login failed: %+v", err)
+		}
+		return v.applyAuth(secret)
+	case AuthMethodKubernetes:
+		jwtPath := v.cfg.KubeJWTMountPath
+		if jwtPath == "" {
+			jwtPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+		}
+		jwt, err := readFile(jwtPath)
+		if err != nil {
+			return fmt.Errorf("vault: error reading kubernetes service account token: %+v", err)
+		}
+		secret, err := v.client.Logical().Write("auth/kubernetes/login", map[string]interface{}{
+			"role": v.cfg.KubeRole,
+			"jwt":  string(jwt),
+		})
+		if err != nil {
+			return fmt.Errorf("vault: kubernetes login failed: %+v", err)
+		}
+		return v.applyAuth(secret)
+	default:
+		return fmt.Errorf("vault: unknown auth method %q", v.cfg.AuthMethod)
+	}
+}
+
+func (v *VaultProvider) applyAuth(secret *vaultapi.Secret) error {
+	if secret == nil || secret.Auth == nil {
+		return fmt.Errorf("vault: login returned no auth info")
+	}
+	v.client.SetToken(secret.Auth.ClientToken)
+	v.leaseTTL = time.Duration(secret.Auth.LeaseDuration) * time.Second
+	v.renewedAt = timeNow()
+	return nil
+}
+
+// ensureFresh re-logs in if the cached lease has expired.
+func (v *VaultProvider) ensureFresh() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.cfg.AuthMethod == AuthMethodToken || v.leaseTTL == 0 {
+		return nil
+	}
+	if timeNow().Sub(v.renewedAt) < v.leaseTTL {
+		return nil
+	}
+	return v.login()
+}
+
+// readSecret reads a single string field from the configured KV v2 path.
+func (v *VaultProvider) readSecret(field string) (string, error) {
+	if err := v.ensureFresh(); err != nil {
+		return "", err
+	}
+	secret, err := v.client.Logical().Read(v.cfg.Path)
+	if err != nil {
+		return "", fmt.Errorf("vault: error reading %s: %+v", v.cfg.Path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("vault: no secret found at %s", v.cfg.Path)
+	}
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("vault: unexpected KV v2 payload shape at %s", v.cfg.Path)
+	}
+	val, ok := data[field].(string)
+	if !ok {
+		return "", fmt.Errorf("vault: field %q not found at %s", field, v.cfg.Path)
+	}
+	return val, nil
+}
+
+// KeystorePassword implements store.SecretsProvider.
+func (v *VaultProvider) KeystorePassword() (string, error) {
+	return v.readSecret("keystore_password")
+}
+
+// APICredentials implements store.SecretsProvider.
+func (v *VaultProvider) APICredentials() (string, string, error) {
+	email, err := v.readSecret("api_email")
+	if err != nil {
+		return "", "", err
+	}
+	password, err := v.readSecret("api_password")
+	if err != nil {
+		return "", "", err
+	}
+	return email, password, nil
+}
+
+// ImportedKey implements store.SecretsProvider. name addresses a field in
+// the same KV v2 secret holding the raw keystore JSON to import.
+func (v *VaultProvider) ImportedKey(name string) ([]byte, error) {
+	val, err := v.readSecret(name)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(val), nil
+}
@@ -0,0 +1,124 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeVault serves just enough of the Vault HTTP API for VaultProvider:
+// AppRole login and a KV v2 read. Each login issues a new token and counts
+// how many times it's been called, so tests can assert on lease renewal.
+type fakeVault struct {
+	logins     int
+	leaseSecs  int
+	secretData map[string]interface{}
+}
+
+func (f *fakeVault) handler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v1/auth/approle/login":
+		f.logins++
+		resp := map[string]interface{}{
+			"auth": map[string]interface{}{
+				"client_token":   fmt.Sprintf("token-%d", f.logins),
+				"lease_duration": f.leaseSecs,
+			},
+		}
+		json.NewEncoder(w).Encode(resp) // nolint:errcheck
+	case "/v1/secret/data/chainlink/prod":
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": f.secretData,
+			},
+		}
+		json.NewEncoder(w).Encode(resp) // nolint:errcheck
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestProvider(t *testing.T, fv *fakeVault) (*VaultProvider, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(fv.handler))
+
+	cfg := VaultConfig{
+		Address:    server.URL,
+		Path:       "secret/data/chainlink/prod",
+		AuthMethod: AuthMethodAppRole,
+		RoleID:     "test-role",
+		SecretID:   "test-secret",
+	}
+	v, err := NewVaultProvider(cfg)
+	if err != nil {
+		server.Close()
+		t.Fatalf("error constructing VaultProvider: %+v", err)
+	}
+	return v, server
+}
+
+func TestVaultProvider_KeystorePassword(t *testing.T) {
+	fv := &fakeVault{leaseSecs: 60, secretData: map[string]interface{}{"keystore_password": "hunter2"}}
+	v, server := newTestProvider(t, fv)
+	defer server.Close()
+
+	pwd, err := v.KeystorePassword()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if pwd != "hunter2" {
+		t.Fatalf("expected password %q, got %q", "hunter2", pwd)
+	}
+	if fv.logins != 1 {
+		t.Fatalf("expected exactly 1 login, got %d", fv.logins)
+	}
+}
+
+func TestVaultProvider_DoesNotRenewBeforeLeaseExpiry(t *testing.T) {
+	defer func() { timeNow = time.Now }()
+
+	fv := &fakeVault{leaseSecs: 60, secretData: map[string]interface{}{"keystore_password": "hunter2"}}
+	v, server := newTestProvider(t, fv)
+	defer server.Close()
+
+	base := time.Now()
+	timeNow = func() time.Time { return base }
+
+	if _, err := v.KeystorePassword(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	// Still well within the 60s lease.
+	timeNow = func() time.Time { return base.Add(30 * time.Second) }
+	if _, err := v.KeystorePassword(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if fv.logins != 1 {
+		t.Fatalf("expected no re-login inside the lease window, got %d logins", fv.logins)
+	}
+}
+
+func TestVaultProvider_RenewsAfterLeaseExpiry(t *testing.T) {
+	defer func() { timeNow = time.Now }()
+
+	fv := &fakeVault{leaseSecs: 60, secretData: map[string]interface{}{"keystore_password": "hunter2"}}
+	v, server := newTestProvider(t, fv)
+	defer server.Close()
+
+	base := time.Now()
+	timeNow = func() time.Time { return base }
+	if _, err := v.KeystorePassword(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	// Past the 60s lease: the next read should trigger a re-login.
+	timeNow = func() time.Time { return base.Add(61 * time.Second) }
+	if _, err := v.KeystorePassword(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if fv.logins != 2 {
+		t.Fatalf("expected a re-login after the lease expired, got %d logins", fv.logins)
+	}
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// ErrSecretNotFound is returned by FileSecretsProvider.APICredentials when
+// no API credentials file is configured.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretsProvider abstracts where Chainlink reads sensitive material from:
+// the keystore password, API credentials, and keys to import. The default
+// FileSecretsProvider preserves the historical on-disk behaviour; other
+// implementations (e.g. Vault) let operators avoid dropping any of this
+// material to disk.
+type SecretsProvider interface {
+	// KeystorePassword returns the password used to unlock the node's
+	// keystore.
+	KeystorePassword() (string, error)
+	// APICredentials returns the email/password pair used to seed or
+	// authenticate the node's HTTP API user.
+	APICredentials() (email, password string, err error)
+	// ImportedKey returns the raw bytes of a keystore JSON file that should
+	// be imported. What name addresses is up to the implementation: a
+	// filesystem path for FileSecretsProvider, a secret field for Vault.
+	ImportedKey(name string) ([]byte, error)
+}
+
+// FileSecretsProvider reads secrets from local files, preserving the
+// pre-existing behaviour of passwordFromFile and the file-based API
+// initializers.
+type FileSecretsProvider struct {
+	PasswordFile string
+	APICredsFile string
+}
+
+// NewFileSecretsProvider returns a SecretsProvider backed by on-disk files.
+func NewFileSecretsProvider(passwordFile, apiCredsFile string) *FileSecretsProvider {
+	return &FileSecretsProvider{
+		PasswordFile: passwordFile,
+		APICredsFile: apiCredsFile,
+	}
+}
+
+// KeystorePassword implements SecretsProvider.
+func (f *FileSecretsProvider) KeystorePassword() (string, error) {
+	if len(f.PasswordFile) == 0 {
+		return "", nil
+	}
+	dat, err := ioutil.ReadFile(f.PasswordFile)
+	return strings.TrimSpace(string(dat)), err
+}
+
+// APICredentials implements SecretsProvider, reading the node's .api
+// credentials file: email on the first line, password on the second, the
+// same format FallbackAPIInitializer's file-based path already expects.
+func (f *FileSecretsProvider) APICredentials() (string, string, error) {
+	if len(f.APICredsFile) == 0 {
+		return "", "", ErrSecretNotFound
+	}
+	dat, err := ioutil.ReadFile(f.APICredsFile)
+	if err != nil {
+		return "", "", err
+	}
+	lines := strings.SplitN(strings.TrimSpace(string(dat)), "\n", 2)
+	if len(lines) != 2 {
+		return "", "", fmt.Errorf("malformed api credentials file %s: expected email and password on separate lines", f.APICredsFile)
+	}
+	return strings.TrimSpace(lines[0]), strings.TrimSpace(lines[1]), nil
+}
+
+// ImportedKey implements SecretsProvider.
+func (f *FileSecretsProvider) ImportedKey(name string) ([]byte, error) {
+	return ioutil.ReadFile(name)
+}